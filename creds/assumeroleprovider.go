@@ -0,0 +1,81 @@
+package creds
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+// Executor is satisfied by executors.Executor. It is declared independently
+// here, rather than imported, to avoid a cycle between creds and executors
+type Executor interface {
+	Execute() (Creds, error)
+}
+
+// DefaultRefreshWindow is how far ahead of expiry AssumeRoleProvider
+// re-invokes its Executor when one is not explicitly configured
+const DefaultRefreshWindow = 5 * time.Minute
+
+// AssumeRoleProvider implements the AWS SDK's credentials.Provider
+// interface by wrapping an Executor and re-calling Execute() once the
+// cached credentials are within RefreshWindow of expiry
+type AssumeRoleProvider struct {
+	Executor      Executor
+	RefreshWindow time.Duration
+
+	mu    sync.Mutex
+	creds Creds
+}
+
+// Retrieve returns the current credentials, refreshing them via Executor
+// first if they are missing or within RefreshWindow of expiry
+func (p *AssumeRoleProvider) Retrieve() (credentials.Value, error) {
+	c, err := p.current()
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	return credentials.Value{
+		AccessKeyID:     c.AccessKey,
+		SecretAccessKey: c.SecretKey,
+		SessionToken:    c.SessionToken,
+		ProviderName:    "AssumeRoleProvider",
+	}, nil
+}
+
+// current returns the full cached Creds (including Expiration), refreshing
+// them via Executor first if they are missing or within RefreshWindow of
+// expiry. Unlike Retrieve, it exposes Expiration, for callers (eg Serve)
+// that need to report it downstream
+func (p *AssumeRoleProvider) current() (Creds, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.isExpiredLocked() {
+		c, err := p.Executor.Execute()
+		if err != nil {
+			return Creds{}, err
+		}
+		p.creds = c
+	}
+	return p.creds, nil
+}
+
+// IsExpired returns whether the cached credentials are within RefreshWindow
+// of expiry
+func (p *AssumeRoleProvider) IsExpired() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isExpiredLocked()
+}
+
+func (p *AssumeRoleProvider) isExpiredLocked() bool {
+	if p.creds.AccessKey == "" || p.creds.Expiration.IsZero() {
+		return true
+	}
+	window := p.RefreshWindow
+	if window == 0 {
+		window = DefaultRefreshWindow
+	}
+	return time.Now().Add(window).After(p.creds.Expiration)
+}
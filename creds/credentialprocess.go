@@ -0,0 +1,27 @@
+package creds
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type credentialProcessOutput struct {
+	Version         int
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      string
+}
+
+// ToCredentialProcess returns the JSON envelope expected by the AWS SDK's
+// credential_process mechanism, suitable for wiring speculate directly into
+// a credential_process line in ~/.aws/config
+func (c Creds) ToCredentialProcess() ([]byte, error) {
+	return json.Marshal(credentialProcessOutput{
+		Version:         1,
+		AccessKeyID:     c.AccessKey,
+		SecretAccessKey: c.SecretKey,
+		SessionToken:    c.SessionToken,
+		Expiration:      c.Expiration.UTC().Format(time.RFC3339),
+	})
+}
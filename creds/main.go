@@ -9,6 +9,8 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -19,6 +21,7 @@ import (
 // Creds defines a set of AWS credentials
 type Creds struct {
 	AccessKey, SecretKey, SessionToken, Region string
+	Expiration                                 time.Time
 }
 
 // New initializes credentials from a map
@@ -41,11 +44,18 @@ func New(argCreds map[string]string) (Creds, error) {
 
 // NewFromStsSdk initializes a credential object from an AWS SDK Credentials object
 func NewFromStsSdk(stsCreds *sts.Credentials) (Creds, error) {
-	return New(map[string]string{
+	c, err := New(map[string]string{
 		"AccessKey":    *stsCreds.AccessKeyId,
 		"SecretKey":    *stsCreds.SecretAccessKey,
 		"SessionToken": *stsCreds.SessionToken,
 	})
+	if err != nil {
+		return Creds{}, err
+	}
+	if stsCreds.Expiration != nil {
+		c.Expiration = *stsCreds.Expiration
+	}
+	return c, nil
 }
 
 // NewFromEnv initializes credentials from the environment variables
@@ -94,11 +104,18 @@ func (c Creds) Translate(dictionary map[string]string) map[string]string {
 	return new
 }
 
-// ToSdk returns an AWS SDK Credentials object
+// ToSdk returns a static AWS SDK Credentials object matching the values
+// held in c at the time of the call
 func (c *Creds) ToSdk() *credentials.Credentials {
 	return credentials.NewStaticCredentials(c.AccessKey, c.SecretKey, c.SessionToken)
 }
 
+// ToRefreshingSdk returns an AWS SDK Credentials object that transparently
+// refreshes itself via executor's AssumeRoleProvider as it approaches expiry
+func (c *Creds) ToRefreshingSdk(executor Executor) *credentials.Credentials {
+	return credentials.NewCredentials(&AssumeRoleProvider{Executor: executor, creds: *c})
+}
+
 // ToEnvVars returns environment variables suitable for eval-ing into the shell
 func (c Creds) ToEnvVars() []string {
 	envCreds := c.Translate(Translations["envvar"])
@@ -112,8 +129,6 @@ func (c Creds) ToEnvVars() []string {
 	return res
 }
 
-var consoleTokenURL = "https://signin.%s.com" // #nosec
-
 type consoleTokenResponse struct {
 	SigninToken string
 }
@@ -131,11 +146,11 @@ func (c Creds) toConsoleToken() (string, error) {
 	args = append(args, paramCreds)
 
 	argString := strings.Join(args, "&")
-	namespace, err := c.namespace()
+	endpoints, err := c.partitionEndpoints()
 	if err != nil {
 		return "", err
 	}
-	baseURL := fmt.Sprintf(consoleTokenURL, namespace)
+	baseURL := "https://" + endpoints.SigninHost
 	url := strings.Join([]string{baseURL, "/federation", argString}, "")
 
 	resp, err := http.Get(url)
@@ -172,16 +187,16 @@ func (c Creds) ToCustomConsoleURL(dest string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	namespace, err := c.namespace()
+	endpoints, err := c.partitionEndpoints()
 	if err != nil {
 		return "", err
 	}
-	baseURL := fmt.Sprintf(consoleTokenURL, namespace)
+	baseURL := "https://" + endpoints.SigninHost
 	var targetURL string
 	if c.Region != "" {
-		targetURL = fmt.Sprintf("https://%s.console.%s.com/%s", c.Region, namespace, dest)
+		targetURL = fmt.Sprintf("https://%s.%s/%s", c.Region, endpoints.ConsoleHost, dest)
 	} else {
-		targetURL = fmt.Sprintf("https://console.%s.com/%s", namespace, dest)
+		targetURL = fmt.Sprintf("https://%s/%s", endpoints.ConsoleHost, dest)
 	}
 	urlParts := []string{
 		baseURL,
@@ -199,12 +214,12 @@ func (c Creds) ToCustomConsoleURL(dest string) (string, error) {
 
 // ToSignoutURL returns a signout URL for the console
 func (c Creds) ToSignoutURL() (string, error) {
-	namespace, err := c.namespace()
+	endpoints, err := c.partitionEndpoints()
 	if err != nil {
 		return "", err
 	}
-	baseURL := fmt.Sprintf(consoleTokenURL, namespace)
-	url := strings.Join([]string{baseURL, "/oauth?Action=logout"}, "")
+	baseURL := "https://" + endpoints.SigninHost
+	url := strings.Join([]string{baseURL, endpoints.SignoutPath}, "")
 	return url, nil
 }
 
@@ -239,21 +254,63 @@ func (c Creds) partition() (string, error) {
 	return pieces[1], nil
 }
 
-func (c Creds) namespace() (string, error) {
+// PartitionEndpoints describes the signin/console hostnames and signout
+// path used to build console URLs for an AWS partition
+type PartitionEndpoints struct {
+	SigninHost  string
+	ConsoleHost string
+	SignoutPath string
+}
+
+// RegisterPartition registers (or overrides) the signin/console endpoints
+// used for partition, so callers on isolated or custom AWS partitions can
+// plug in their own endpoints without patching this module
+func RegisterPartition(partition string, endpoints PartitionEndpoints) {
+	partitionEndpointsMu.Lock()
+	defer partitionEndpointsMu.Unlock()
+	partitionEndpointsByPartition[partition] = endpoints
+}
+
+func (c Creds) partitionEndpoints() (PartitionEndpoints, error) {
 	partition, err := c.partition()
 	if err != nil {
-		return "", err
+		return PartitionEndpoints{}, err
 	}
-	result, ok := namespaces[partition]
+	return partitionEndpointsFor(partition)
+}
+
+// partitionEndpointsFor looks up the registered endpoints for partition,
+// split out from partitionEndpoints so it can be tested without an STS call
+func partitionEndpointsFor(partition string) (PartitionEndpoints, error) {
+	partitionEndpointsMu.RLock()
+	defer partitionEndpointsMu.RUnlock()
+	result, ok := partitionEndpointsByPartition[partition]
 	if ok {
 		return result, nil
 	}
-	return "", fmt.Errorf("unknown partition: %s", partition)
+	return PartitionEndpoints{}, fmt.Errorf("unknown partition: %s", partition)
 }
 
-var namespaces = map[string]string{
-	"aws":        "aws.amazon",
-	"aws-us-gov": "amazonaws-us-gov",
+// partitionEndpointsMu guards partitionEndpointsByPartition, since
+// RegisterPartition may be called concurrently with partitionEndpoints
+var partitionEndpointsMu sync.RWMutex
+
+var partitionEndpointsByPartition = map[string]PartitionEndpoints{
+	"aws": {
+		SigninHost:  "signin.aws.amazon.com",
+		ConsoleHost: "console.aws.amazon.com",
+		SignoutPath: "/oauth?Action=logout",
+	},
+	"aws-us-gov": {
+		SigninHost:  "signin.amazonaws-us-gov.com",
+		ConsoleHost: "console.amazonaws-us-gov.com",
+		SignoutPath: "/oauth?Action=logout",
+	},
+	"aws-cn": {
+		SigninHost:  "signin.amazonaws.cn",
+		ConsoleHost: "console.amazonaws.cn",
+		SignoutPath: "/oauth?Action=logout",
+	},
 }
 
 // AccountID returns the user's account ID
@@ -0,0 +1,89 @@
+package creds
+
+import "testing"
+
+func TestPartitionEndpointsFor(t *testing.T) {
+	cases := []struct {
+		name      string
+		partition string
+		want      PartitionEndpoints
+	}{
+		{
+			name:      "aws",
+			partition: "aws",
+			want: PartitionEndpoints{
+				SigninHost:  "signin.aws.amazon.com",
+				ConsoleHost: "console.aws.amazon.com",
+				SignoutPath: "/oauth?Action=logout",
+			},
+		},
+		{
+			name:      "aws-cn",
+			partition: "aws-cn",
+			want: PartitionEndpoints{
+				SigninHost:  "signin.amazonaws.cn",
+				ConsoleHost: "console.amazonaws.cn",
+				SignoutPath: "/oauth?Action=logout",
+			},
+		},
+		{
+			name:      "aws-us-gov",
+			partition: "aws-us-gov",
+			want: PartitionEndpoints{
+				SigninHost:  "signin.amazonaws-us-gov.com",
+				ConsoleHost: "console.amazonaws-us-gov.com",
+				SignoutPath: "/oauth?Action=logout",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := partitionEndpointsFor(tc.partition)
+			if err != nil {
+				t.Fatalf("partitionEndpointsFor(%q) returned error: %s", tc.partition, err)
+			}
+			if got != tc.want {
+				t.Errorf("partitionEndpointsFor(%q) = %+v, want %+v", tc.partition, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPartitionEndpointsForUnknown(t *testing.T) {
+	if _, err := partitionEndpointsFor("aws-nonexistent"); err == nil {
+		t.Error("expected an error for an unregistered partition, got nil")
+	}
+}
+
+func TestRegisterPartition(t *testing.T) {
+	custom := PartitionEndpoints{
+		SigninHost:  "signin.example.com",
+		ConsoleHost: "console.example.com",
+		SignoutPath: "/logout",
+	}
+	RegisterPartition("aws-custom-test", custom)
+
+	got, err := partitionEndpointsFor("aws-custom-test")
+	if err != nil {
+		t.Fatalf("partitionEndpointsFor() returned error after RegisterPartition: %s", err)
+	}
+	if got != custom {
+		t.Errorf("partitionEndpointsFor() = %+v, want %+v", got, custom)
+	}
+
+	overridden := PartitionEndpoints{
+		SigninHost:  "signin.example.org",
+		ConsoleHost: "console.example.org",
+		SignoutPath: "/signout",
+	}
+	RegisterPartition("aws-custom-test", overridden)
+
+	got, err = partitionEndpointsFor("aws-custom-test")
+	if err != nil {
+		t.Fatalf("partitionEndpointsFor() returned error after re-registering: %s", err)
+	}
+	if got != overridden {
+		t.Errorf("partitionEndpointsFor() after override = %+v, want %+v", got, overridden)
+	}
+}
@@ -0,0 +1,165 @@
+package creds
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ServeOptions configures the local credentials server started by Serve
+type ServeOptions struct {
+	BindAddr  string // address:port to listen on, eg "127.0.0.1:0" or "169.254.169.254:80"
+	Role      string // role name exposed under the IMDS security-credentials path
+	AuthToken string // if set, required via the Authorization header on the ECS endpoint
+
+	// Executor, if set, is used to transparently re-assume the role once the
+	// served credentials are within AssumeRoleProvider's refresh window of
+	// expiry. Without it, Serve keeps returning the static snapshot it was
+	// started with, Expiration included, for the life of the process.
+	Executor Executor
+
+	// OnListen, if set, is called once with the bound address before Serve
+	// blocks, so callers can recover a kernel-assigned port from ":0"
+	OnListen func(addr string)
+}
+
+const (
+	imdsRoleListPath = "/latest/meta-data/iam/security-credentials/"
+	ecsCredsPath     = "/ecs/credentials"
+)
+
+type imdsCredentials struct {
+	Code            string
+	LastUpdated     string
+	Type            string
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string
+	Token           string
+	Expiration      string
+}
+
+type ecsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string
+	Token           string
+	Expiration      string
+}
+
+func (c Creds) imdsBody() imdsCredentials {
+	return imdsCredentials{
+		Code:            "Success",
+		LastUpdated:     time.Now().UTC().Format(time.RFC3339),
+		Type:            "AWS-HMAC",
+		AccessKeyID:     c.AccessKey,
+		SecretAccessKey: c.SecretKey,
+		Token:           c.SessionToken,
+		Expiration:      c.Expiration.UTC().Format(time.RFC3339),
+	}
+}
+
+func (c Creds) ecsBody() ecsCredentials {
+	return ecsCredentials{
+		AccessKeyID:     c.AccessKey,
+		SecretAccessKey: c.SecretKey,
+		Token:           c.SessionToken,
+		Expiration:      c.Expiration.UTC().Format(time.RFC3339),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handler builds the mux serving the IMDS and ECS credential endpoints. Each
+// request fetches creds via source, so that an Executor-backed source can
+// transparently refresh the underlying assume-role session before expiry.
+func (c Creds) handler(opts ServeOptions) http.Handler {
+	source := func() (Creds, error) { return c, nil }
+	if opts.Executor != nil {
+		provider := &AssumeRoleProvider{Executor: opts.Executor, creds: c}
+		source = provider.current
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(imdsRoleListPath, func(w http.ResponseWriter, r *http.Request) {
+		role := strings.TrimPrefix(r.URL.Path, imdsRoleListPath)
+		if role == "" {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(opts.Role))
+			return
+		}
+		if role != opts.Role {
+			http.NotFound(w, r)
+			return
+		}
+		current, err := source()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, current.imdsBody())
+	})
+
+	mux.HandleFunc(ecsCredsPath, func(w http.ResponseWriter, r *http.Request) {
+		if opts.AuthToken != "" && r.Header.Get("Authorization") != opts.AuthToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		current, err := source()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, current.ecsBody())
+	})
+
+	return mux
+}
+
+// Serve starts a local HTTP server exposing these credentials via both the
+// EC2 instance metadata service protocol and the ECS credential provider
+// protocol. If opts.Executor is set, the role is transparently re-assumed
+// as the session approaches expiry; otherwise c is served statically for
+// the life of the process. Serve blocks until the process receives
+// SIGINT/SIGTERM, at which point it shuts down cleanly.
+func (c Creds) Serve(opts ServeOptions) error {
+	ln, err := net.Listen("tcp", opts.BindAddr)
+	if err != nil {
+		return err
+	}
+	if opts.OnListen != nil {
+		opts.OnListen(ln.Addr().String())
+	}
+
+	srv := &http.Server{Handler: c.handler(opts)}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// ECSCredentialsURI returns the value to set AWS_CONTAINER_CREDENTIALS_FULL_URI
+// to when pointing a child process at a server started with Serve
+func ECSCredentialsURI(bindAddr string) string {
+	return "http://" + bindAddr + ecsCredsPath
+}
@@ -0,0 +1,164 @@
+package creds
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testCreds() Creds {
+	return Creds{
+		AccessKey:    "AKIATEST",
+		SecretKey:    "secret",
+		SessionToken: "token",
+		Expiration:   time.Unix(0, 0).UTC(),
+	}
+}
+
+func TestHandlerIMDSRoleList(t *testing.T) {
+	c := testCreds()
+	h := c.handler(ServeOptions{Role: "speculate"})
+
+	req := httptest.NewRequest(http.MethodGet, imdsRoleListPath, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "speculate" {
+		t.Errorf("body = %q, want %q", got, "speculate")
+	}
+}
+
+func TestHandlerIMDSRoleCreds(t *testing.T) {
+	c := testCreds()
+	h := c.handler(ServeOptions{Role: "speculate"})
+
+	req := httptest.NewRequest(http.MethodGet, imdsRoleListPath+"speculate", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body imdsCredentials
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if body.AccessKeyID != c.AccessKey {
+		t.Errorf("AccessKeyId = %q, want %q", body.AccessKeyID, c.AccessKey)
+	}
+}
+
+func TestHandlerIMDSWrongRole(t *testing.T) {
+	c := testCreds()
+	h := c.handler(ServeOptions{Role: "speculate"})
+
+	req := httptest.NewRequest(http.MethodGet, imdsRoleListPath+"other-role", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestHandlerECSCreds(t *testing.T) {
+	c := testCreds()
+	h := c.handler(ServeOptions{Role: "speculate"})
+
+	req := httptest.NewRequest(http.MethodGet, ecsCredsPath, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body ecsCredentials
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if body.AccessKeyID != c.AccessKey {
+		t.Errorf("AccessKeyId = %q, want %q", body.AccessKeyID, c.AccessKey)
+	}
+}
+
+type stubExecutor struct {
+	calls int
+	creds Creds
+}
+
+func (e *stubExecutor) Execute() (Creds, error) {
+	e.calls++
+	return e.creds, nil
+}
+
+func TestHandlerWithExecutorRefreshesExpiredCreds(t *testing.T) {
+	stale := testCreds()
+	fresh := Creds{
+		AccessKey:    "AKIAFRESH",
+		SecretKey:    "fresh-secret",
+		SessionToken: "fresh-token",
+		Expiration:   time.Now().Add(time.Hour),
+	}
+	executor := &stubExecutor{creds: fresh}
+	h := stale.handler(ServeOptions{Role: "speculate", Executor: executor})
+
+	req := httptest.NewRequest(http.MethodGet, ecsCredsPath, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body ecsCredentials
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if body.AccessKeyID != fresh.AccessKey {
+		t.Errorf("AccessKeyId = %q, want refreshed value %q", body.AccessKeyID, fresh.AccessKey)
+	}
+	if executor.calls != 1 {
+		t.Errorf("executor.calls = %d, want 1", executor.calls)
+	}
+
+	// A second request with still-fresh creds must not re-invoke the executor
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if executor.calls != 1 {
+		t.Errorf("executor.calls after second request = %d, want 1 (no redundant refresh)", executor.calls)
+	}
+}
+
+func TestHandlerECSAuthToken(t *testing.T) {
+	c := testCreds()
+	h := c.handler(ServeOptions{Role: "speculate", AuthToken: "secret-token"})
+
+	cases := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing header", "", http.StatusUnauthorized},
+		{"wrong token", "wrong", http.StatusUnauthorized},
+		{"correct token", "secret-token", http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, ecsCredsPath, nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Errorf("status = %d, want %d", rec.Code, tc.want)
+			}
+		})
+	}
+}
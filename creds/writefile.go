@@ -0,0 +1,109 @@
+package creds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var profileHeaderRegex = regexp.MustCompile(`(?m)^\[.*\]\s*$`)
+
+// WriteToFile writes these credentials into the standard AWS shared
+// credentials file at path, under the given profile name. An existing
+// [profile] block is replaced in place; otherwise the block is appended.
+// Other profiles and comments in the file are left untouched. The write is
+// atomic (tempfile + rename) and the resulting file is mode 0600.
+func (c Creds) WriteToFile(path, profile string) error {
+	existing, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	updated := replaceProfileBlock(string(existing), profile, c.profileBlock(profile))
+	return atomicWriteFile(path, []byte(updated), 0600)
+}
+
+func (c Creds) profileBlock(profile string) string {
+	lines := []string{fmt.Sprintf("[%s]", profile)}
+	if !c.Expiration.IsZero() {
+		lines = append(lines, fmt.Sprintf("# Expiration = %s", c.Expiration.UTC().Format(time.RFC3339)))
+	}
+	lines = append(lines,
+		fmt.Sprintf("aws_access_key_id = %s", c.AccessKey),
+		fmt.Sprintf("aws_secret_access_key = %s", c.SecretKey),
+		fmt.Sprintf("aws_session_token = %s", c.SessionToken),
+	)
+	if c.Region != "" {
+		lines = append(lines, fmt.Sprintf("region = %s", c.Region))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replaceProfileBlock replaces the [profile] block in content with block,
+// or appends block if no matching header is found
+func replaceProfileBlock(content, profile, block string) string {
+	header := fmt.Sprintf("[%s]", profile)
+	var lines []string
+	if content != "" {
+		lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	}
+
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == header {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		lines = append(lines, block)
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if profileHeaderRegex.MatchString(lines[i]) {
+			end = i
+			break
+		}
+	}
+
+	newLines := append([]string{}, lines[:start]...)
+	newLines = append(newLines, block)
+	newLines = append(newLines, lines[end:]...)
+	return strings.Join(newLines, "\n") + "\n"
+}
+
+// atomicWriteFile writes data to a tempfile in the same directory as path
+// and renames it into place, to avoid leaving a partial file on failure
+func atomicWriteFile(path string, data []byte, mode os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".speculate-creds-")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,51 @@
+package creds
+
+import "testing"
+
+func TestReplaceProfileBlock(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		profile string
+		block   string
+		want    string
+	}{
+		{
+			name:    "empty file appends block",
+			content: "",
+			profile: "default",
+			block:   "[default]\nfoo = bar",
+			want:    "[default]\nfoo = bar\n",
+		},
+		{
+			name:    "no matching profile appends block",
+			content: "[other]\nfoo = bar\n",
+			profile: "default",
+			block:   "[default]\nfoo = baz",
+			want:    "[other]\nfoo = bar\n[default]\nfoo = baz\n",
+		},
+		{
+			name:    "matching profile is replaced in place",
+			content: "[before]\na = 1\n[default]\nfoo = old\n[after]\nb = 2\n",
+			profile: "default",
+			block:   "[default]\nfoo = new",
+			want:    "[before]\na = 1\n[default]\nfoo = new\n[after]\nb = 2\n",
+		},
+		{
+			name:    "matching profile at end of file is replaced",
+			content: "[before]\na = 1\n[default]\nfoo = old\n",
+			profile: "default",
+			block:   "[default]\nfoo = new",
+			want:    "[before]\na = 1\n[default]\nfoo = new\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := replaceProfileBlock(tc.content, tc.profile, tc.block)
+			if got != tc.want {
+				t.Errorf("replaceProfileBlock(%q, %q, %q) = %q, want %q", tc.content, tc.profile, tc.block, got, tc.want)
+			}
+		})
+	}
+}
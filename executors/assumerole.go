@@ -0,0 +1,79 @@
+package executors
+
+import (
+	"github.com/akerl/speculate/v2/creds"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// AssumeRoleExecutor requests credentials via sts:AssumeRole, authenticating
+// with an existing AWS identity (environment, shared config, or an
+// explicitly provided Creds)
+type AssumeRoleExecutor struct {
+	RoleTarget
+	Lifetime
+	Mfa
+}
+
+// Execute performs sts:AssumeRole, sourcing the calling identity from the
+// environment/shared AWS config
+func (e *AssumeRoleExecutor) Execute() (creds.Creds, error) {
+	c, err := creds.NewFromEnv()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	return e.ExecuteWithCreds(c)
+}
+
+// ExecuteWithCreds performs sts:AssumeRole using the provided creds as the
+// calling identity
+func (e *AssumeRoleExecutor) ExecuteWithCreds(c creds.Creds) (creds.Creds, error) {
+	roleName, err := e.GetRoleName()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	accountID, err := e.GetAccountID()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	roleArn, err := c.NextRoleArn(roleName, accountID)
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	sessionName, err := e.GetSessionName()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	if sessionName == "" {
+		sessionName, err = c.SessionName()
+		if err != nil {
+			return creds.Creds{}, err
+		}
+	}
+	lifetime, err := e.GetLifetime()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+
+	params := &sts.AssumeRoleInput{
+		RoleArn:         &roleArn,
+		RoleSessionName: &sessionName,
+		DurationSeconds: &lifetime,
+	}
+	policy, err := e.GetPolicy()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	if policy != "" {
+		params.Policy = &policy
+	}
+	if err := e.configureMfa(params); err != nil {
+		return creds.Creds{}, err
+	}
+
+	resp, err := c.Client().AssumeRole(params)
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	return creds.NewFromStsSdk(resp.Credentials)
+}
@@ -0,0 +1,55 @@
+package executors
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// CredentialProcessCmd assumes a role via sts:AssumeRole and prints the
+// resulting credentials in the JSON envelope expected by the AWS SDK's
+// credential_process mechanism (not mounted to a root command here; see
+// ServeCmd's doc comment for the embedding pattern).
+var CredentialProcessCmd = &cobra.Command{
+	Use:   "credential-process",
+	Short: "Print credentials as a credential_process JSON envelope",
+	RunE:  runCredentialProcessCmd,
+}
+
+var (
+	credentialProcessAccountID string
+	credentialProcessRoleName  string
+	credentialProcessSession   string
+)
+
+func init() {
+	flags := CredentialProcessCmd.Flags()
+	flags.StringVar(&credentialProcessAccountID, "account-id", "", "target account ID (defaults to the caller's own account)")
+	flags.StringVar(&credentialProcessRoleName, "role", "", "name of the role to assume (required)")
+	flags.StringVar(&credentialProcessSession, "session-name", "", "name for the new session")
+	_ = CredentialProcessCmd.MarkFlagRequired("role")
+}
+
+func runCredentialProcessCmd(cmd *cobra.Command, args []string) error {
+	e := &AssumeRoleExecutor{}
+	if err := e.SetAccountID(credentialProcessAccountID); err != nil {
+		return err
+	}
+	if err := e.SetRoleName(credentialProcessRoleName); err != nil {
+		return err
+	}
+	if err := e.SetSessionName(credentialProcessSession); err != nil {
+		return err
+	}
+
+	c, err := e.Execute()
+	if err != nil {
+		return err
+	}
+	out, err := c.ToCredentialProcess()
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
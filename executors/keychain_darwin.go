@@ -0,0 +1,20 @@
+package executors
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// DefaultKeychainReader reads OATH secrets from the macOS login keychain via
+// the `security` CLI
+type DefaultKeychainReader struct{}
+
+// Read returns the generic-password secret for account under the
+// "speculate" keychain service
+func (DefaultKeychainReader) Read(account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", "speculate", "-a", account, "-w").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
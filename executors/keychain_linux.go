@@ -0,0 +1,19 @@
+package executors
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// DefaultKeychainReader reads OATH secrets from the freedesktop Secret
+// Service via the `secret-tool` CLI (libsecret-tools)
+type DefaultKeychainReader struct{}
+
+// Read returns the secret stored under the "speculate" service for account
+func (DefaultKeychainReader) Read(account string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", "speculate", "account", account).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
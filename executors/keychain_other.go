@@ -0,0 +1,15 @@
+//go:build !darwin && !linux
+// +build !darwin,!linux
+
+package executors
+
+import "fmt"
+
+// DefaultKeychainReader is unimplemented on this platform; pass a custom
+// KeychainReader to KeychainMfaPrompt instead
+type DefaultKeychainReader struct{}
+
+// Read always fails on unsupported platforms
+func (DefaultKeychainReader) Read(account string) (string, error) {
+	return "", fmt.Errorf("keychain MFA prompt is not supported on this platform")
+}
@@ -7,9 +7,9 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/akerl/speculate/creds"
+	"github.com/akerl/speculate/v2/creds"
 
-	"github.com/akerl/timber/log"
+	"github.com/akerl/timber/v2/log"
 	"github.com/aws/aws-sdk-go/service/sts"
 )
 
@@ -76,10 +76,11 @@ func (l *Lifetime) GetLifetime() (int64, error) {
 
 // Mfa object encapsulates the setup of MFA for API calls
 type Mfa struct {
-	useMfa    bool
-	mfaSerial string
-	mfaCode   string
-	mfaPrompt MfaPrompt
+	useMfa          bool
+	mfaSerial       string
+	mfaCode         string
+	mfaCodeExplicit bool
+	mfaPrompt       MfaPrompt
 }
 
 // MfaPrompt interface describes an object which can prompt the user for their MFA
@@ -109,6 +110,7 @@ func (m *Mfa) SetMfaCode(val string) error {
 	if val == "" || mfaCodeRegex.MatchString(val) {
 		logger.InfoMsg(fmt.Sprintf("Setting MFA code: %s", val))
 		m.mfaCode = val
+		m.mfaCodeExplicit = val != ""
 		return nil
 	}
 	return fmt.Errorf("MFA Code is malformed: %s", val)
@@ -143,7 +145,11 @@ func (m *Mfa) GetMfaSerial() (string, error) {
 	return m.mfaSerial, nil
 }
 
-// GetMfaCode returns the OTP to use
+// GetMfaCode returns the OTP to use. A code obtained from the MFA prompt is
+// single-use: it is cleared once returned, so a re-invoking Executor (eg one
+// wrapped in AssumeRoleProvider) calls the prompt again for a fresh code
+// rather than resending a stale one. A code set explicitly via SetMfaCode is
+// reused as-is, since there is no prompt to refresh it from.
 func (m *Mfa) GetMfaCode() (string, error) {
 	if m.mfaCode == "" {
 		mfaPrompt, err := m.GetMfaPrompt()
@@ -157,7 +163,11 @@ func (m *Mfa) GetMfaCode() (string, error) {
 		}
 		m.mfaCode = mfa
 	}
-	return m.mfaCode, nil
+	code := m.mfaCode
+	if !m.mfaCodeExplicit {
+		m.mfaCode = ""
+	}
+	return code, nil
 }
 
 // GetMfaPrompt returns the function to use for asking the user for an MFA code
@@ -208,6 +218,8 @@ func (m *Mfa) configureMfa(paramsIface interface{}) error {
 	case *sts.GetSessionTokenInput:
 		params.TokenCode = &mfaCode
 		params.SerialNumber = &mfaSerial
+	case *sts.AssumeRoleWithSAMLInput, *sts.AssumeRoleWithWebIdentityInput:
+		return fmt.Errorf("MFA is not supported for SAML or web identity executors")
 	default:
 		return fmt.Errorf("expected AssumeRoleInput or GetSessionTokenInput, received %T", params)
 	}
@@ -0,0 +1,55 @@
+package executors
+
+import "testing"
+
+type sequencePrompt struct {
+	codes []string
+	calls int
+}
+
+func (p *sequencePrompt) Prompt() (string, error) {
+	code := p.codes[p.calls]
+	p.calls++
+	return code, nil
+}
+
+func TestGetMfaCodePromptedIsSingleUse(t *testing.T) {
+	prompt := &sequencePrompt{codes: []string{"111111", "222222"}}
+	m := &Mfa{mfaPrompt: prompt}
+
+	first, err := m.GetMfaCode()
+	if err != nil {
+		t.Fatalf("GetMfaCode() returned error: %s", err)
+	}
+	if first != "111111" {
+		t.Errorf("first GetMfaCode() = %q, want %q", first, "111111")
+	}
+
+	second, err := m.GetMfaCode()
+	if err != nil {
+		t.Fatalf("GetMfaCode() returned error: %s", err)
+	}
+	if second != "222222" {
+		t.Errorf("second GetMfaCode() = %q, want %q (a stale code would mean the prompt wasn't re-invoked)", second, "222222")
+	}
+	if prompt.calls != 2 {
+		t.Errorf("prompt.calls = %d, want 2", prompt.calls)
+	}
+}
+
+func TestGetMfaCodeExplicitIsReused(t *testing.T) {
+	m := &Mfa{}
+	if err := m.SetMfaCode("123456"); err != nil {
+		t.Fatalf("SetMfaCode() returned error: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		code, err := m.GetMfaCode()
+		if err != nil {
+			t.Fatalf("GetMfaCode() returned error: %s", err)
+		}
+		if code != "123456" {
+			t.Errorf("GetMfaCode() call %d = %q, want %q", i+1, code, "123456")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package executors
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	shellwords "github.com/mattn/go-shellwords"
+)
+
+// ExecMfaPrompt runs a user-configured command and reads the 6-digit code
+// from its stdout, eg `ykman oath accounts code <name>`
+type ExecMfaPrompt struct {
+	Command string
+}
+
+// Prompt runs the configured command and returns its trimmed stdout
+func (p *ExecMfaPrompt) Prompt() (string, error) {
+	args, err := shellwords.Parse(p.Command)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("MFA exec command is empty")
+	}
+
+	out, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// KeychainReader retrieves an OATH secret for the given account from an OS
+// keychain
+type KeychainReader interface {
+	Read(account string) (string, error)
+}
+
+// KeychainMfaPrompt computes a TOTP code locally from an OATH secret stored
+// in the OS keychain, following RFC 6238
+type KeychainMfaPrompt struct {
+	Account string
+	Reader  KeychainReader
+}
+
+// Prompt fetches the OATH secret for Account and derives the current TOTP code
+func (p *KeychainMfaPrompt) Prompt() (string, error) {
+	reader := p.Reader
+	if reader == nil {
+		reader = DefaultKeychainReader{}
+	}
+	secret, err := reader.Read(p.Account)
+	if err != nil {
+		return "", err
+	}
+	return totp(secret, time.Now())
+}
+
+// totp derives the 6-digit RFC 6238 TOTP code for secret (a base32-encoded
+// OATH key) at the given time, using the standard 30s step and HMAC-SHA1
+func totp(secret string, at time.Time) (string, error) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode MFA secret: %s", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", truncated%1000000), nil
+}
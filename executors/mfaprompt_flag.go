@@ -0,0 +1,36 @@
+package executors
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// MfaPromptEnvVar is the environment variable fallback for --mfa-prompt
+const MfaPromptEnvVar = "SPECULATE_MFA_PROMPT"
+
+// AddMfaPromptFlags registers the --mfa-prompt, --mfa-exec-command and
+// --mfa-keychain-account flags on cmd, returning pointers to their values
+func AddMfaPromptFlags(cmd *cobra.Command) (prompt, execCommand, keychainAccount *string) {
+	flags := cmd.Flags()
+	prompt = flags.String("mfa-prompt", os.Getenv(MfaPromptEnvVar), "MFA prompt to use: default, exec, or keychain")
+	execCommand = flags.String("mfa-exec-command", "", "command to run for the exec MFA prompt")
+	keychainAccount = flags.String("mfa-keychain-account", "", "keychain account name for the keychain MFA prompt")
+	return
+}
+
+// ResolveMfaPrompt builds the MfaPrompt implementation selected by name,
+// defaulting to DefaultMfaPrompt when name is empty
+func ResolveMfaPrompt(name, execCommand, keychainAccount string) (MfaPrompt, error) {
+	switch name {
+	case "", "default":
+		return &DefaultMfaPrompt{}, nil
+	case "exec":
+		return &ExecMfaPrompt{Command: execCommand}, nil
+	case "keychain":
+		return &KeychainMfaPrompt{Account: keychainAccount}, nil
+	default:
+		return nil, fmt.Errorf("unknown MFA prompt: %s", name)
+	}
+}
@@ -0,0 +1,38 @@
+package executors
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTotp(t *testing.T) {
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	cases := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"RFC 6238 test vector at t=59", time.Unix(59, 0).UTC(), "287082"},
+		{"RFC 6238 test vector at t=1111111109", time.Unix(1111111109, 0).UTC(), "081804"},
+		{"RFC 6238 test vector at t=1111111111", time.Unix(1111111111, 0).UTC(), "050471"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := totp(secret, tc.at)
+			if err != nil {
+				t.Fatalf("totp() returned error: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("totp() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTotpInvalidSecret(t *testing.T) {
+	if _, err := totp("not-valid-base32!", time.Unix(59, 0)); err == nil {
+		t.Error("expected an error for an invalid base32 secret, got nil")
+	}
+}
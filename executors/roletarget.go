@@ -0,0 +1,83 @@
+package executors
+
+import "fmt"
+
+// RoleTarget encapsulates the account, role, session name, and policy for
+// an assumed role. It is embedded by Executor implementations that produce
+// role credentials via some non-MFA STS call (eg web identity or SAML)
+type RoleTarget struct {
+	accountID   string
+	roleName    string
+	sessionName string
+	policy      string
+}
+
+// SetAccountID sets the target account ID
+func (t *RoleTarget) SetAccountID(val string) error {
+	if val != "" && !accountIDRegex.MatchString(val) {
+		return fmt.Errorf("account ID is malformed: %s", val)
+	}
+	logger.InfoMsg(fmt.Sprintf("Setting account ID: %s", val))
+	t.accountID = val
+	return nil
+}
+
+// GetAccountID returns the target account ID
+func (t *RoleTarget) GetAccountID() (string, error) {
+	return t.accountID, nil
+}
+
+// SetRoleName sets the name of the role to assume
+func (t *RoleTarget) SetRoleName(val string) error {
+	if val != "" && !iamEntityRegex.MatchString(val) {
+		return fmt.Errorf("role name is malformed: %s", val)
+	}
+	logger.InfoMsg(fmt.Sprintf("Setting role name: %s", val))
+	t.roleName = val
+	return nil
+}
+
+// GetRoleName returns the name of the role to assume
+func (t *RoleTarget) GetRoleName() (string, error) {
+	return t.roleName, nil
+}
+
+// SetSessionName sets the name for the new session
+func (t *RoleTarget) SetSessionName(val string) error {
+	if val != "" && !iamEntityRegex.MatchString(val) {
+		return fmt.Errorf("session name is malformed: %s", val)
+	}
+	logger.InfoMsg(fmt.Sprintf("Setting session name: %s", val))
+	t.sessionName = val
+	return nil
+}
+
+// GetSessionName returns the name for the new session
+func (t *RoleTarget) GetSessionName() (string, error) {
+	return t.sessionName, nil
+}
+
+// SetPolicy sets the inline session policy
+func (t *RoleTarget) SetPolicy(val string) error {
+	logger.InfoMsg("Setting policy")
+	t.policy = val
+	return nil
+}
+
+// GetPolicy returns the inline session policy
+func (t *RoleTarget) GetPolicy() (string, error) {
+	return t.policy, nil
+}
+
+// RoleArn returns the ARN of the target role. Unlike creds.Creds.NextRoleArn,
+// this does not look up the caller's partition or account, since these
+// executors authenticate without any pre-existing AWS identity
+func (t *RoleTarget) RoleArn() (string, error) {
+	if t.roleName == "" {
+		return "", fmt.Errorf("role name cannot be empty")
+	}
+	if t.accountID == "" {
+		return "", fmt.Errorf("account ID cannot be empty")
+	}
+	return fmt.Sprintf("arn:aws:iam::%s:role/%s", t.accountID, t.roleName), nil
+}
@@ -0,0 +1,89 @@
+package executors
+
+import (
+	"fmt"
+
+	"github.com/akerl/speculate/v2/creds"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// SAMLProvider supplies a base64-encoded SAML assertion for
+// sts:AssumeRoleWithSAML, letting integrators plug in ADFS, Okta, or
+// any other SAML identity provider
+type SAMLProvider interface {
+	Assertion() (string, error)
+}
+
+// SAMLExecutor requests credentials via sts:AssumeRoleWithSAML, using either
+// a static base64 SAML Assertion or a Provider that fetches one. It never
+// uses MFA.
+type SAMLExecutor struct {
+	RoleTarget
+	Lifetime
+	Mfa
+
+	Region       string
+	PrincipalArn string
+	Assertion    string
+	Provider     SAMLProvider
+}
+
+func (e *SAMLExecutor) assertion() (string, error) {
+	if e.Provider != nil {
+		return e.Provider.Assertion()
+	}
+	if e.Assertion != "" {
+		return e.Assertion, nil
+	}
+	return "", fmt.Errorf("SAML assertion not configured")
+}
+
+// Execute performs sts:AssumeRoleWithSAML and returns the resulting creds
+func (e *SAMLExecutor) Execute() (creds.Creds, error) {
+	return e.ExecuteWithCreds(creds.Creds{})
+}
+
+// ExecuteWithCreds performs sts:AssumeRoleWithSAML. The provided creds are
+// ignored, since SAML calls authenticate via the assertion rather than an
+// existing AWS identity.
+func (e *SAMLExecutor) ExecuteWithCreds(_ creds.Creds) (creds.Creds, error) {
+	if e.PrincipalArn == "" {
+		return creds.Creds{}, fmt.Errorf("principal ARN cannot be empty")
+	}
+	roleArn, err := e.RoleArn()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	assertion, err := e.assertion()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	lifetime, err := e.GetLifetime()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+
+	params := &sts.AssumeRoleWithSAMLInput{
+		RoleArn:         &roleArn,
+		PrincipalArn:    &e.PrincipalArn,
+		SAMLAssertion:   &assertion,
+		DurationSeconds: &lifetime,
+	}
+	policy, err := e.GetPolicy()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	if policy != "" {
+		params.Policy = &policy
+	}
+	if err := e.configureMfa(params); err != nil {
+		return creds.Creds{}, err
+	}
+
+	resp, err := unauthenticatedSTSClient(e.Region).AssumeRoleWithSAML(params)
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	return creds.NewFromStsSdk(resp.Credentials)
+}
@@ -0,0 +1,54 @@
+package executors
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// SAMLCmd assumes a role via sts:AssumeRoleWithSAML (not mounted to a root
+// command here; see ServeCmd's doc comment for the embedding pattern).
+var SAMLCmd = &cobra.Command{
+	Use:   "saml",
+	Short: "Assume a role using a SAML assertion",
+	RunE:  runSAMLCmd,
+}
+
+var (
+	samlAccountID string
+	samlRoleName  string
+	samlSession   string
+	samlRegion    string
+	samlPrincipal string
+	samlAssertion string
+)
+
+func init() {
+	flags := SAMLCmd.Flags()
+	flags.StringVar(&samlAccountID, "account-id", "", "target account ID")
+	flags.StringVar(&samlRoleName, "role", "", "name of the role to assume")
+	flags.StringVar(&samlSession, "session-name", "", "name for the new session")
+	flags.StringVar(&samlRegion, "region", "", "AWS region for the STS call")
+	flags.StringVar(&samlPrincipal, "principal-arn", "", "ARN of the SAML identity provider")
+	flags.StringVar(&samlAssertion, "assertion", "", "base64-encoded SAML assertion")
+}
+
+func runSAMLCmd(cmd *cobra.Command, args []string) error {
+	e := &SAMLExecutor{Region: samlRegion, PrincipalArn: samlPrincipal, Assertion: samlAssertion}
+	if err := e.SetAccountID(samlAccountID); err != nil {
+		return err
+	}
+	if err := e.SetRoleName(samlRoleName); err != nil {
+		return err
+	}
+	if err := e.SetSessionName(samlSession); err != nil {
+		return err
+	}
+
+	c, err := e.Execute()
+	if err != nil {
+		return err
+	}
+	for _, line := range c.ToEnvVars() {
+		cmd.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package executors
+
+import (
+	"github.com/akerl/speculate/v2/creds"
+
+	"github.com/spf13/cobra"
+)
+
+var serveBindAddr string
+var serveIMDSRole string
+var serveAuthToken string
+var serveAccountID string
+var serveRoleName string
+var serveSessionName string
+
+// ServeCmd exposes creds.Serve as a speculate subcommand. It is not mounted
+// to a root command here; callers embedding this package wire it into their
+// own CLI via AddCommand.
+var ServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve credentials locally via the EC2 IMDS and ECS credential protocols",
+	RunE:  runServeCmd,
+}
+
+func init() {
+	flags := ServeCmd.Flags()
+	flags.StringVar(&serveBindAddr, "bind", "127.0.0.1:0", "address:port to listen on")
+	flags.StringVar(&serveIMDSRole, "imds-role", "speculate", "role name exposed under the IMDS security-credentials path")
+	flags.StringVar(&serveAuthToken, "auth-token", "", "require this value via the Authorization header on the ECS endpoint")
+	flags.StringVar(&serveAccountID, "account-id", "", "target account ID (defaults to the caller's own account)")
+	flags.StringVar(&serveRoleName, "role", "", "name of the role to assume and keep refreshed (required)")
+	flags.StringVar(&serveSessionName, "session-name", "", "name for the new session")
+	_ = ServeCmd.MarkFlagRequired("role")
+}
+
+func runServeCmd(cmd *cobra.Command, args []string) error {
+	e := &AssumeRoleExecutor{}
+	if err := e.SetAccountID(serveAccountID); err != nil {
+		return err
+	}
+	if err := e.SetRoleName(serveRoleName); err != nil {
+		return err
+	}
+	if err := e.SetSessionName(serveSessionName); err != nil {
+		return err
+	}
+
+	c, err := e.Execute()
+	if err != nil {
+		return err
+	}
+	return c.Serve(creds.ServeOptions{
+		BindAddr:  serveBindAddr,
+		Role:      serveIMDSRole,
+		AuthToken: serveAuthToken,
+		Executor:  e,
+		OnListen: func(addr string) {
+			cmd.Println("listening on", addr)
+			cmd.Println("AWS_CONTAINER_CREDENTIALS_FULL_URI=" + creds.ECSCredentialsURI(addr))
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package executors
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// unauthenticatedSTSClient returns an STS client with no static credentials,
+// for calls like AssumeRoleWithWebIdentity/AssumeRoleWithSAML that
+// authenticate via their own parameters rather than an existing AWS identity
+func unauthenticatedSTSClient(region string) *sts.STS {
+	config := aws.NewConfig()
+	if region != "" {
+		config.WithRegion(region)
+	}
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	}))
+	return sts.New(sess)
+}
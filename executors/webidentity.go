@@ -0,0 +1,104 @@
+package executors
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+
+	"github.com/akerl/speculate/v2/creds"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	shellwords "github.com/mattn/go-shellwords"
+)
+
+// WebIdentityExecutor requests credentials via sts:AssumeRoleWithWebIdentity,
+// using an OIDC token read from TokenFile or produced by TokenCommand,
+// mirroring the SDK's AWS_WEB_IDENTITY_TOKEN_FILE support. It never uses MFA.
+type WebIdentityExecutor struct {
+	RoleTarget
+	Lifetime
+	Mfa
+
+	Region       string
+	TokenFile    string
+	TokenCommand string
+}
+
+func (e *WebIdentityExecutor) token() (string, error) {
+	if e.TokenCommand != "" {
+		args, err := shellwords.Parse(e.TokenCommand)
+		if err != nil {
+			return "", err
+		}
+		if len(args) == 0 {
+			return "", fmt.Errorf("web identity token command is empty")
+		}
+		out, err := exec.Command(args[0], args[1:]...).Output()
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+	if e.TokenFile != "" {
+		data, err := ioutil.ReadFile(e.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", fmt.Errorf("web identity token source not configured")
+}
+
+// Execute performs sts:AssumeRoleWithWebIdentity and returns the resulting creds
+func (e *WebIdentityExecutor) Execute() (creds.Creds, error) {
+	return e.ExecuteWithCreds(creds.Creds{})
+}
+
+// ExecuteWithCreds performs sts:AssumeRoleWithWebIdentity. The provided
+// creds are ignored, since web identity calls authenticate via the token
+// rather than an existing AWS identity.
+func (e *WebIdentityExecutor) ExecuteWithCreds(_ creds.Creds) (creds.Creds, error) {
+	roleArn, err := e.RoleArn()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	token, err := e.token()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	sessionName, err := e.GetSessionName()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	if sessionName == "" {
+		sessionName = "speculate"
+	}
+	lifetime, err := e.GetLifetime()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+
+	params := &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          &roleArn,
+		RoleSessionName:  &sessionName,
+		WebIdentityToken: &token,
+		DurationSeconds:  &lifetime,
+	}
+	policy, err := e.GetPolicy()
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	if policy != "" {
+		params.Policy = &policy
+	}
+	if err := e.configureMfa(params); err != nil {
+		return creds.Creds{}, err
+	}
+
+	resp, err := unauthenticatedSTSClient(e.Region).AssumeRoleWithWebIdentity(params)
+	if err != nil {
+		return creds.Creds{}, err
+	}
+	return creds.NewFromStsSdk(resp.Credentials)
+}
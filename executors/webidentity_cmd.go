@@ -0,0 +1,55 @@
+package executors
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// WebIdentityCmd assumes a role via sts:AssumeRoleWithWebIdentity (not
+// mounted to a root command here; see ServeCmd's doc comment for the
+// embedding pattern).
+var WebIdentityCmd = &cobra.Command{
+	Use:   "web-identity",
+	Short: "Assume a role using an OIDC web identity token",
+	RunE:  runWebIdentityCmd,
+}
+
+var (
+	webIdentityAccountID string
+	webIdentityRoleName  string
+	webIdentitySession   string
+	webIdentityRegion    string
+	webIdentityTokenFile string
+	webIdentityTokenCmd  string
+)
+
+func init() {
+	flags := WebIdentityCmd.Flags()
+	flags.StringVar(&webIdentityAccountID, "account-id", "", "target account ID")
+	flags.StringVar(&webIdentityRoleName, "role", "", "name of the role to assume")
+	flags.StringVar(&webIdentitySession, "session-name", "", "name for the new session")
+	flags.StringVar(&webIdentityRegion, "region", "", "AWS region for the STS call")
+	flags.StringVar(&webIdentityTokenFile, "token-file", "", "path to the OIDC token file")
+	flags.StringVar(&webIdentityTokenCmd, "token-command", "", "command to run to fetch the OIDC token")
+}
+
+func runWebIdentityCmd(cmd *cobra.Command, args []string) error {
+	e := &WebIdentityExecutor{Region: webIdentityRegion, TokenFile: webIdentityTokenFile, TokenCommand: webIdentityTokenCmd}
+	if err := e.SetAccountID(webIdentityAccountID); err != nil {
+		return err
+	}
+	if err := e.SetRoleName(webIdentityRoleName); err != nil {
+		return err
+	}
+	if err := e.SetSessionName(webIdentitySession); err != nil {
+		return err
+	}
+
+	c, err := e.Execute()
+	if err != nil {
+		return err
+	}
+	for _, line := range c.ToEnvVars() {
+		cmd.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package executors
+
+import (
+	"github.com/akerl/speculate/v2/creds"
+
+	"github.com/spf13/cobra"
+)
+
+var writeFilePath string
+var writeFileProfile string
+
+// WriteCmd writes credentials into the standard AWS shared credentials file
+// (not mounted to a root command here; see ServeCmd's doc comment for the
+// embedding pattern).
+var WriteCmd = &cobra.Command{
+	Use:   "write",
+	Short: "Write credentials into the AWS shared credentials file",
+	RunE:  runWriteCmd,
+}
+
+func init() {
+	flags := WriteCmd.Flags()
+	flags.StringVar(&writeFilePath, "file", "", "path to the shared credentials file (required)")
+	flags.StringVar(&writeFileProfile, "profile", "", "name of the profile to write (required)")
+	_ = WriteCmd.MarkFlagRequired("file")
+	_ = WriteCmd.MarkFlagRequired("profile")
+}
+
+func runWriteCmd(cmd *cobra.Command, args []string) error {
+	c, err := creds.NewFromEnv()
+	if err != nil {
+		return err
+	}
+	return c.WriteToFile(writeFilePath, writeFileProfile)
+}